@@ -0,0 +1,95 @@
+package xxsmux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// tagMiddleware returns a Middleware that appends name to order before
+// calling through, so a test can assert the effective middleware stack
+// ran in the right order.
+func tagMiddleware(order *[]string, name string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*order = append(*order, name)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestWithDoesNotMutateParent(t *testing.T) {
+	var order []string
+
+	mux := NewXXSMux()
+	mux.Use(tagMiddleware(&order, "parent"))
+
+	mux.With(tagMiddleware(&order, "with")).HandleFunc("GET /with", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("GET /plain", func(w http.ResponseWriter, r *http.Request) {})
+	mux.Build()
+
+	order = nil
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/plain", nil))
+	if got := order; len(got) != 1 || got[0] != "parent" {
+		t.Fatalf("order for /plain = %v, want [parent] (With must not have mutated mux's own middlewares)", got)
+	}
+
+	order = nil
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/with", nil))
+	if got := order; len(got) != 2 || got[0] != "parent" || got[1] != "with" {
+		t.Fatalf("order for /with = %v, want [parent with]", got)
+	}
+}
+
+func TestGroupScopesMiddlewareAndIsNotItselfRoutable(t *testing.T) {
+	var order []string
+
+	mux := NewXXSMux()
+	before := len(mux.root.registeredPatterns)
+
+	mux.Group(func(r *XXSMux) {
+		r.Use(tagMiddleware(&order, "grouped"))
+		r.HandleFunc("GET /grouped", func(w http.ResponseWriter, r *http.Request) {})
+	})
+	mux.HandleFunc("GET /ungrouped", func(w http.ResponseWriter, r *http.Request) {})
+	mux.Build()
+
+	if got := len(mux.root.registeredPatterns) - before; got != 2 {
+		t.Fatalf("registered %d patterns, want 2 (Group itself registers nothing)", got)
+	}
+
+	order = nil
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/grouped", nil))
+	if got := order; len(got) != 1 || got[0] != "grouped" {
+		t.Fatalf("order for /grouped = %v, want [grouped]", got)
+	}
+
+	order = nil
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ungrouped", nil))
+	if len(order) != 0 {
+		t.Fatalf("order for /ungrouped = %v, want [] (Group's middleware must not leak out)", order)
+	}
+}
+
+func TestRouteMountsAtPrefixWithEffectiveMiddlewareStack(t *testing.T) {
+	var order []string
+
+	mux := NewXXSMux()
+	mux.Use(tagMiddleware(&order, "outer"))
+
+	mux.Route("/api", func(r *XXSMux) {
+		r.Use(tagMiddleware(&order, "inner"))
+		r.HandleFunc("GET /ping", func(w http.ResponseWriter, r *http.Request) {})
+	})
+	mux.Build()
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/ping", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (route should be mounted at /api/ping)", rec.Code, http.StatusOK)
+	}
+	if got := order; len(got) != 2 || got[0] != "outer" || got[1] != "inner" {
+		t.Fatalf("order = %v, want [outer inner] (ancestors' middleware first, in registration order)", got)
+	}
+}
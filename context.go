@@ -0,0 +1,66 @@
+package xxsmux
+
+import (
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+)
+
+type contextKey int
+
+const (
+	paramsContextKey contextKey = iota
+	formContextKey
+)
+
+// withParams stores the path parameters captured during routing on r's context.
+func withParams(r *http.Request, p params) *http.Request {
+	ctx := context.WithValue(r.Context(), paramsContextKey, p)
+	return r.WithContext(ctx)
+}
+
+// URLParam returns the value of the named path parameter captured while
+// routing r, or the empty string if it was not set.
+func URLParam(r *http.Request, name string) string {
+	p, ok := r.Context().Value(paramsContextKey).(params)
+	if !ok {
+		return ""
+	}
+	return p[name]
+}
+
+// Bind is a middleware that decodes the request body into a new T and
+// stores it on the request context, where it can be retrieved with
+// GetForm. JSON request bodies are decoded with encoding/json; anything
+// else is parsed with r.ParseForm, leaving field population to the caller
+// via the decoded zero value plus r.Form.
+func Bind[T any](next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var v T
+
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if mediaType == "application/json" {
+			if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
+				http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		} else if err := r.ParseForm(); err != nil {
+			http.Error(w, "invalid form: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), formContextKey, &v)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// GetForm returns the value bound to r's context by Bind[T], and whether it was present.
+func GetForm[T any](r *http.Request) (T, bool) {
+	v, ok := r.Context().Value(formContextKey).(*T)
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return *v, true
+}
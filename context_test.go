@@ -0,0 +1,57 @@
+package xxsmux
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type createUserRequest struct {
+	Name string `json:"name"`
+}
+
+func TestBindAndURLParamEndToEnd(t *testing.T) {
+	var gotID string
+	var gotBody createUserRequest
+	var gotOK bool
+
+	mux := NewXXSMux()
+	mux.With(Bind[createUserRequest]).HandleFunc("POST /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		gotID = URLParam(r, "id")
+		gotBody, gotOK = GetForm[createUserRequest](r)
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.Build()
+
+	req := httptest.NewRequest(http.MethodPost, "/users/42", bytes.NewBufferString(`{"name":"ada"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+	if gotID != "42" {
+		t.Fatalf(`URLParam("id") = %q, want %q`, gotID, "42")
+	}
+	if !gotOK {
+		t.Fatal("GetForm reported no value bound, want the body Bind decoded")
+	}
+	if gotBody.Name != "ada" {
+		t.Fatalf("bound body Name = %q, want %q", gotBody.Name, "ada")
+	}
+}
+
+func TestGetFormMissingReturnsZeroAndFalse(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	got, ok := GetForm[createUserRequest](req)
+	if ok {
+		t.Fatal("GetForm reported a value bound, want false when Bind never ran")
+	}
+	if got != (createUserRequest{}) {
+		t.Fatalf("GetForm value = %+v, want zero value", got)
+	}
+}
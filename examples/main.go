@@ -0,0 +1,160 @@
+// Command examples demonstrates registering routes, prefixes, subrouters
+// and middlewares on an xxsmux.XXSMux.
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/42LM/xxsmux"
+)
+
+func main() {
+	router := xxsmux.NewXXSMux()
+	router.Use(Middleware1, Middleware4)
+
+	// /v1/test
+	// /v1/a
+	// /v1/b
+	router.Prefix("v1")
+	router.HandleFunc("GET /test", greet)
+	router.HandleFunc("GET /a", greet)
+	router.HandleFunc("GET /b", greet)
+
+	// /v1/v2/{instance_id}/test
+	v1Router := router.Subrouter()
+	v1Router.Prefix("v2/{instance_id}")
+	v1Router.HandleFunc("GET /test", greet)
+
+	// /v1/v2/{instance_id}/foo
+	v12Router := v1Router.Subrouter()
+	v12Router.Use(Middleware3)
+	v12Router.HandleFunc("GET /foo", greet)
+
+	// /v1/v2/{instance_id}/foobar/bar
+	v13Router := v12Router.Subrouter()
+	v13Router.Use(Middleware3)
+	v13Router.Prefix("foobar")
+	v13Router.HandleFunc("GET /bar", greet)
+
+	// /v1/boo/test
+	v2Router := router.Subrouter()
+	v2Router.Prefix("boo")
+	v2Router.Use(Middleware2)
+	v2Router.HandleFunc("GET /test", greet)
+
+	// /v1/secret
+	adminRouter := router.Subrouter()
+	adminRouter.Use(AdminMiddleware)
+	adminRouter.HandleFunc("GET /secret", greet)
+
+	router.Build()
+
+	s := http.Server{
+		Addr:    ":8080",
+		Handler: router,
+	}
+
+	s.ListenAndServe()
+}
+
+func greet(w http.ResponseWriter, r *http.Request) {
+	fmt.Printf("url.Path: %v\n", r.URL.Path)
+	fmt.Printf("url.RawPath: %v\n", r.URL.RawPath)
+	fmt.Printf("url.EscapedPath(): %v\n", r.URL.EscapedPath())
+	name := xxsmux.URLParam(r, "instance_id")
+	fmt.Fprintf(w, "Hello %s", name)
+}
+
+func helloWorld(w http.ResponseWriter, r *http.Request) {
+	fmt.Printf("url.Path: %v\n", r.URL.Path)
+	fmt.Printf("url.RawPath: %v\n", r.URL.RawPath)
+	fmt.Printf("url.EscapedPath(): %v\n", r.URL.EscapedPath())
+	for i := 0; i < 7; i++ {
+		fmt.Fprint(w, "Hello world")
+	}
+}
+
+func secret(w http.ResponseWriter, r *http.Request) {
+	fmt.Printf("url.Path: %v\n", r.URL.Path)
+	fmt.Printf("url.RawPath: %v\n", r.URL.RawPath)
+	fmt.Printf("url.EscapedPath(): %v\n", r.URL.EscapedPath())
+	fmt.Fprintln(w, "Beep Boop Bob hello agent")
+}
+
+func Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+	})
+}
+
+func Middleware1(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "HELLO FROM MIDDLEWARE #1")
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func Middleware2(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "HELLO FROM MIDDLEWARE #2")
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func Middleware3(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "HELLO FROM MIDDLEWARE #3")
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func Middleware4(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "HELLO FROM MIDDLEWARE #4")
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func AdminMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "HELLO FROM ADMIN MIDDLEWARE")
+
+		usr, pw, ok := r.BasicAuth()
+		if !ok {
+			fmt.Fprintln(w, "⚠️ RESTRICTED AREA")
+			return
+		}
+		if usr == "007" && pw == "martini" {
+			next.ServeHTTP(w, r)
+		} else {
+			fmt.Fprintln(w, "AGENT WHO??? 🤣")
+			return
+		}
+	})
+}
+
+func Chain(base http.Handler, middleware ...func(http.Handler) http.Handler) http.Handler {
+	for _, m := range middleware {
+		base = m(base)
+	}
+	return base
+}
+
+func ChainRouter(base http.Handler, handlers ...http.Handler) http.Handler {
+	finalHandler := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r) // Call the next handler
+		})
+	}
+
+	for _, handler := range handlers {
+		base = finalHandler(handler)
+	}
+
+	return base
+}
@@ -0,0 +1,40 @@
+package xxsmux
+
+import "strings"
+
+// matchHost reports whether pattern matches host (which may carry a
+// ":port" suffix that is ignored), and returns the captured subdomain
+// parameter if pattern leads with a "{name}" wildcard label, e.g.
+// "{tenant}.example.com" matching "acme.example.com:8443".
+func matchHost(pattern, host string) (capture params, ok bool) {
+	host = stripPort(host)
+
+	if !strings.HasPrefix(pattern, "{") {
+		return nil, pattern == host
+	}
+
+	end := strings.IndexByte(pattern, '}')
+	if end == -1 {
+		return nil, false
+	}
+	name := pattern[1:end]
+	suffix := pattern[end+1:]
+
+	if !strings.HasSuffix(host, suffix) {
+		return nil, false
+	}
+	value := strings.TrimSuffix(host, suffix)
+	if value == "" || strings.Contains(value, ".") {
+		// Only a single subdomain label is captured; reject "a.b.example.com".
+		return nil, false
+	}
+
+	return params{name: value}, true
+}
+
+func stripPort(host string) string {
+	if i := strings.LastIndexByte(host, ':'); i != -1 {
+		return host[:i]
+	}
+	return host
+}
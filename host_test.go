@@ -0,0 +1,49 @@
+package xxsmux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLookupPrefersLiteralHostOverWildcard(t *testing.T) {
+	mux := NewXXSMux()
+	mux.Host("api.example.com").HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("literal"))
+	})
+	mux.Host("{tenant}.example.com").HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("wildcard"))
+	})
+	mux.Build()
+
+	req := httptest.NewRequest(http.MethodGet, "http://api.example.com/", nil)
+	req.Host = "api.example.com"
+
+	for i := 0; i < 50; i++ {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		if got := rec.Body.String(); got != "literal" {
+			t.Fatalf("iteration %d: body = %q, want %q (literal host must win over wildcard)", i, got, "literal")
+		}
+	}
+}
+
+func TestLookupFallsBackToWildcardHost(t *testing.T) {
+	mux := NewXXSMux()
+	mux.Host("api.example.com").HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("literal"))
+	})
+	mux.Host("{tenant}.example.com").HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("wildcard:" + URLParam(r, "tenant")))
+	})
+	mux.Build()
+
+	req := httptest.NewRequest(http.MethodGet, "http://acme.example.com/", nil)
+	req.Host = "acme.example.com"
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	if got := rec.Body.String(); got != "wildcard:acme" {
+		t.Fatalf("body = %q, want %q", got, "wildcard:acme")
+	}
+}
@@ -0,0 +1,32 @@
+package xxsmux
+
+// Param describes a single request parameter for documentation purposes.
+// See RouteMeta and Route.Describe.
+type Param struct {
+	Name string
+	In   string // "path", "query", "header" or "cookie"
+	Type string // OpenAPI schema type, e.g. "integer", "string"
+}
+
+// RouteMeta carries route documentation consumed by spec.Generate. It's
+// attached to a route with Route.Describe and has no effect on routing or
+// serving itself.
+type RouteMeta struct {
+	Summary   string
+	Params    []Param
+	Responses map[int]any // status code -> example value its schema is inferred from
+}
+
+// Route is returned by Handle/HandleFunc so callers can attach
+// documentation to the route they just registered.
+type Route struct {
+	ep *endpoint
+}
+
+// Describe attaches m to the route, for spec.Generate to pick up. It
+// returns rt so calls can be chained off Handle, e.g.
+// mux.Handle(pattern, h).Describe(xxsmux.RouteMeta{Summary: "..."}).
+func (rt *Route) Describe(m RouteMeta) *Route {
+	rt.ep.meta = m
+	return rt
+}
@@ -0,0 +1,50 @@
+package xxsmux
+
+import (
+	"net/http"
+	"testing"
+)
+
+// structHandler is a value-receiver http.Handler, the kind that made the
+// old reflect.ValueOf(h).Pointer()-based metadata lookup panic.
+type structHandler struct{}
+
+func (structHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {}
+
+func TestWalkDoesNotPanicOnStructValuedHandler(t *testing.T) {
+	mux := NewXXSMux()
+	mux.Handle("GET /ping", structHandler{})
+	mux.Build()
+
+	err := mux.Walk(func(method, pattern string, handler http.Handler, mws []Middleware, meta RouteMeta) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk returned error: %v", err)
+	}
+}
+
+func TestDescribeDoesNotCollideAcrossRoutesSharingAHandler(t *testing.T) {
+	shared := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	mux := NewXXSMux()
+	mux.Handle("GET /a", shared).Describe(RouteMeta{Summary: "a summary"})
+	mux.Handle("GET /b", shared).Describe(RouteMeta{Summary: "b summary"})
+	mux.Build()
+
+	got := map[string]string{}
+	err := mux.Walk(func(method, pattern string, handler http.Handler, mws []Middleware, meta RouteMeta) error {
+		got[pattern] = meta.Summary
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk returned error: %v", err)
+	}
+
+	if got["/a"] != "a summary" {
+		t.Fatalf(`meta for "/a" = %q, want "a summary"`, got["/a"])
+	}
+	if got["/b"] != "b summary" {
+		t.Fatalf(`meta for "/b" = %q, want "b summary"`, got["/b"])
+	}
+}
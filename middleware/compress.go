@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// compressWriter wraps a ResponseWriter, transparently compressing
+// whatever gets written to it and fixing up the response headers.
+type compressWriter struct {
+	http.ResponseWriter
+	w           io.Writer
+	encoding    string
+	types       []string
+	wroteHeader bool
+	pass        bool // true once we've decided the response shouldn't be compressed
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+
+	if !cw.shouldCompress() {
+		cw.pass = true
+		cw.ResponseWriter.WriteHeader(status)
+		return
+	}
+
+	cw.Header().Del("Content-Length")
+	cw.Header().Set("Content-Encoding", cw.encoding)
+	cw.Header().Add("Vary", "Accept-Encoding")
+	cw.ResponseWriter.WriteHeader(status)
+}
+
+func (cw *compressWriter) shouldCompress() bool {
+	if len(cw.types) == 0 {
+		return true
+	}
+	contentType := cw.Header().Get("Content-Type")
+	for _, t := range cw.types {
+		if strings.HasPrefix(contentType, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (cw *compressWriter) Write(b []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if cw.pass {
+		return cw.ResponseWriter.Write(b)
+	}
+	return cw.w.Write(b)
+}
+
+// Close flushes the underlying encoder, unless the response ended up
+// passed through uncompressed — in which case the encoder was never
+// written to, and closing it anyway would append a gzip/flate
+// header+trailer after the real, uncompressed body.
+func (cw *compressWriter) Close() error {
+	if cw.pass {
+		return nil
+	}
+	if c, ok := cw.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Compress is a middleware that gzip- or deflate-compresses response
+// bodies, negotiated against the request's Accept-Encoding header. level
+// is the compress/gzip and compress/flate compression level (e.g.
+// gzip.DefaultCompression). If types is non-empty, only responses whose
+// Content-Type has one of the given prefixes are compressed.
+func Compress(level int, types ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var enc io.WriteCloser
+			switch encoding {
+			case "gzip":
+				gz, err := gzip.NewWriterLevel(w, level)
+				if err != nil {
+					next.ServeHTTP(w, r)
+					return
+				}
+				enc = gz
+			case "deflate":
+				fl, err := flate.NewWriter(w, level)
+				if err != nil {
+					next.ServeHTTP(w, r)
+					return
+				}
+				enc = fl
+			}
+			cw := &compressWriter{ResponseWriter: w, w: enc, encoding: encoding, types: types}
+			next.ServeHTTP(cw, r)
+			cw.Close()
+		})
+	}
+}
+
+// negotiateEncoding picks gzip or deflate out of an Accept-Encoding header,
+// preferring gzip, or "" if neither is acceptable.
+func negotiateEncoding(acceptEncoding string) string {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		switch strings.TrimSpace(strings.SplitN(part, ";", 2)[0]) {
+		case "gzip":
+			return "gzip"
+		}
+	}
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		switch strings.TrimSpace(strings.SplitN(part, ";", 2)[0]) {
+		case "deflate":
+			return "deflate"
+		}
+	}
+	return ""
+}
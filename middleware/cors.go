@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// CORSOptions configures the CORS middleware.
+type CORSOptions struct {
+	// AllowedOrigins lists origins allowed to make cross-origin requests.
+	// An entry of "*" allows any origin. Entries may contain a single "*"
+	// wildcard, e.g. "https://*.example.com".
+	AllowedOrigins []string
+	// AllowedMethods lists methods allowed for cross-origin requests.
+	AllowedMethods []string
+	// AllowedHeaders lists headers allowed to be sent by the client. An
+	// entry of "*" allows any header.
+	AllowedHeaders []string
+	// AllowCredentials sets Access-Control-Allow-Credentials. Per the
+	// fetch spec, a wildcard origin can't be combined with credentialed
+	// requests, so when true and the origin matched via "*", the actual
+	// request origin is echoed back instead of "*".
+	AllowCredentials bool
+	// MaxAge sets Access-Control-Max-Age, in seconds. Zero omits the header.
+	MaxAge int
+}
+
+// CORS is a middleware that handles Cross-Origin Resource Sharing,
+// including preflight OPTIONS requests, according to opts.
+func CORS(opts CORSOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			matched, wildcard := matchOrigin(opts.AllowedOrigins, origin)
+			if !matched {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			headers := w.Header()
+			headers.Add("Vary", "Origin")
+
+			if opts.AllowCredentials && wildcard {
+				headers.Set("Access-Control-Allow-Origin", origin)
+				headers.Set("Access-Control-Allow-Credentials", "true")
+			} else if opts.AllowCredentials {
+				headers.Set("Access-Control-Allow-Origin", origin)
+				headers.Set("Access-Control-Allow-Credentials", "true")
+			} else if wildcard {
+				headers.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				headers.Set("Access-Control-Allow-Origin", origin)
+			}
+
+			if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+				// Not a preflight: either a simple/actual cross-origin
+				// request, or a plain OPTIONS request the resource
+				// wants to handle itself (e.g. for Allow: discovery).
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Preflight request.
+			headers.Add("Vary", "Access-Control-Request-Method")
+			headers.Add("Vary", "Access-Control-Request-Headers")
+
+			if len(opts.AllowedMethods) > 0 {
+				headers.Set("Access-Control-Allow-Methods", strings.Join(opts.AllowedMethods, ", "))
+			}
+			if len(opts.AllowedHeaders) > 0 {
+				if len(opts.AllowedHeaders) == 1 && opts.AllowedHeaders[0] == "*" {
+					headers.Set("Access-Control-Allow-Headers", r.Header.Get("Access-Control-Request-Headers"))
+				} else {
+					headers.Set("Access-Control-Allow-Headers", strings.Join(opts.AllowedHeaders, ", "))
+				}
+			}
+			if opts.MaxAge > 0 {
+				headers.Set("Access-Control-Max-Age", strconv.Itoa(opts.MaxAge))
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// matchOrigin reports whether origin is allowed by allowed, and whether
+// the match came from a "*" wildcard entry.
+func matchOrigin(allowed []string, origin string) (matched, wildcard bool) {
+	for _, a := range allowed {
+		if a == "*" {
+			return true, true
+		}
+		if a == origin {
+			return true, false
+		}
+		if strings.Contains(a, "*") {
+			if ok, _ := path.Match(a, origin); ok {
+				return true, false
+			}
+		}
+	}
+	return false, false
+}
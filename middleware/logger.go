@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"time"
+)
+
+// Logger is a middleware that logs each request's method, path, status,
+// response size and duration once the handler chain has finished.
+func Logger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ww := NewWrapResponseWriter(w)
+		start := time.Now()
+
+		next.ServeHTTP(ww, r)
+
+		log.Printf(
+			"%s %s %d %dB %s",
+			r.Method, r.URL.Path, ww.Status(), ww.BytesWritten(), time.Since(start),
+		)
+	})
+}
@@ -0,0 +1,6 @@
+// Package middleware provides a set of commonly needed HTTP middlewares
+// for use with xxsmux (or any other net/http-based router): RequestID,
+// RealIP, Logger, Recoverer, Timeout, Compress and CORS. Every middleware
+// here has the shape func(http.Handler) http.Handler, so it drops straight
+// into XXSMux.Use.
+package middleware
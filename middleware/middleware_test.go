@@ -0,0 +1,175 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestIDGeneratesWhenAbsent(t *testing.T) {
+	var gotID string
+	h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotID = GetReqID(r.Context())
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if gotID == "" {
+		t.Fatal("GetReqID returned empty string, want a generated id")
+	}
+	if rec.Header().Get(RequestIDHeader) != gotID {
+		t.Fatalf("response header %s = %q, want %q", RequestIDHeader, rec.Header().Get(RequestIDHeader), gotID)
+	}
+}
+
+func TestRequestIDReusesIncoming(t *testing.T) {
+	h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(RequestIDHeader, "incoming-id")
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get(RequestIDHeader); got != "incoming-id" {
+		t.Fatalf("response header %s = %q, want %q", RequestIDHeader, got, "incoming-id")
+	}
+}
+
+func TestRealIPPrefersXForwardedFor(t *testing.T) {
+	var gotAddr string
+	h := RealIP(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAddr = r.RemoteAddr
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Forwarded-For", "203.0.113.1, 10.0.0.1")
+	req.Header.Set("X-Real-IP", "198.51.100.1")
+
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	if gotAddr != "203.0.113.1" {
+		t.Fatalf("RemoteAddr = %q, want %q", gotAddr, "203.0.113.1")
+	}
+}
+
+func TestRecovererReturns500OnPanic(t *testing.T) {
+	h := Recoverer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestTimeoutLetsFastHandlerThrough(t *testing.T) {
+	h := Timeout(50 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		w.Write([]byte("ok"))
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusTeapot {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusTeapot)
+	}
+	if rec.Body.String() != "ok" {
+		t.Fatalf("body = %q, want %q", rec.Body.String(), "ok")
+	}
+}
+
+func TestTimeoutRespondsServiceUnavailableOnSlowHandler(t *testing.T) {
+	blocked := make(chan struct{})
+	h := Timeout(10 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		// Simulate the handler still trying to write after losing the
+		// race; timeoutWriter must discard this instead of racing the
+		// real ResponseWriter.
+		w.Write([]byte("too late"))
+		close(blocked)
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+	<-blocked
+}
+
+func TestCORSHandlesPreflight(t *testing.T) {
+	h := CORS(CORSOptions{
+		AllowedOrigins: []string{"https://example.com"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("next handler should not run for a CORS preflight request")
+	}))
+
+	req := httptest.NewRequest(http.MethodOptions, "/", nil)
+	req.Header.Set("Origin", "https://example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://example.com" {
+		t.Fatalf("Access-Control-Allow-Origin = %q, want %q", got, "https://example.com")
+	}
+}
+
+func TestCORSDoesNotHijackPlainOPTIONS(t *testing.T) {
+	var ran bool
+	h := CORS(CORSOptions{
+		AllowedOrigins: []string{"https://example.com"},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ran = true
+		w.Header().Set("Allow", "GET, OPTIONS")
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodOptions, "/", nil)
+	req.Header.Set("Origin", "https://example.com")
+	// No Access-Control-Request-Method: this is a plain OPTIONS request,
+	// not a CORS preflight.
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if !ran {
+		t.Fatal("resource's own OPTIONS handler should have run")
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestCORSWildcardWithCredentialsEchoesOrigin(t *testing.T) {
+	h := CORS(CORSOptions{
+		AllowedOrigins:   []string{"*"},
+		AllowCredentials: true,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Origin", "https://example.com")
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://example.com" {
+		t.Fatalf("Access-Control-Allow-Origin = %q, want %q (request origin echoed back)", got, "https://example.com")
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Fatalf("Access-Control-Allow-Credentials = %q, want %q", got, "true")
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// RealIP is a middleware that overwrites r.RemoteAddr with the client IP
+// found in the X-Forwarded-For or X-Real-IP headers, for use behind a
+// reverse proxy or load balancer. X-Forwarded-For is preferred, and its
+// left-most entry (the original client) wins.
+//
+// RealIP must be the outermost middleware handling a request, otherwise
+// it's trivial for a client to spoof its IP by setting these headers
+// directly. Only enable it when the server sits behind a trusted proxy.
+func RealIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+			if ip := strings.TrimSpace(strings.Split(fwd, ",")[0]); ip != "" {
+				r.RemoteAddr = ip
+			}
+		} else if ip := r.Header.Get("X-Real-IP"); ip != "" {
+			r.RemoteAddr = ip
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"runtime/debug"
+)
+
+// Recoverer is a middleware that recovers from panics in the handler
+// chain, logs the panic value and stack trace, and responds with a 500
+// instead of letting net/http's server tear down the connection.
+func Recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rvr := recover(); rvr != nil {
+				log.Printf("panic: %v\n%s", rvr, debug.Stack())
+				http.Error(w, "500 internal server error", http.StatusInternalServerError)
+			}
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import "net/http"
+
+// WrapResponseWriter wraps an http.ResponseWriter to observe the status
+// code and byte count written through it, for use by middlewares that need
+// to report on a response after the fact (e.g. Logger).
+type WrapResponseWriter struct {
+	http.ResponseWriter
+	status       int
+	bytesWritten int
+	wroteHeader  bool
+}
+
+// NewWrapResponseWriter returns a WrapResponseWriter wrapping w.
+func NewWrapResponseWriter(w http.ResponseWriter) *WrapResponseWriter {
+	return &WrapResponseWriter{ResponseWriter: w}
+}
+
+// WriteHeader implements http.ResponseWriter.
+func (w *WrapResponseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write implements http.ResponseWriter.
+func (w *WrapResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytesWritten += n
+	return n, err
+}
+
+// Status returns the status code written, or 200 if none was written yet.
+func (w *WrapResponseWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// BytesWritten returns the number of bytes written to the response body so far.
+func (w *WrapResponseWriter) BytesWritten() int {
+	return w.bytesWritten
+}
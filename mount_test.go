@@ -0,0 +1,73 @@
+package xxsmux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMountStripsPrefix(t *testing.T) {
+	sub := http.NewServeMux()
+	sub.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("pong:" + r.URL.Path))
+	})
+
+	mux := NewXXSMux()
+	mux.Mount("/api", sub)
+	mux.Build()
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/ping", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got := rec.Body.String(); got != "pong:/ping" {
+		t.Fatalf("body = %q, want %q (prefix should be stripped before delegating)", got, "pong:/ping")
+	}
+}
+
+func TestMountNestedXXSMuxBuiltByParent(t *testing.T) {
+	sub := NewXXSMux()
+	sub.HandleFunc("GET /ping", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("pong"))
+	})
+	// sub.Build() deliberately not called: mounting it under a parent that
+	// gets Build()'d must be enough.
+
+	mux := NewXXSMux()
+	mux.Mount("/api", sub)
+	mux.Build()
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/ping", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got := rec.Body.String(); got != "pong" {
+		t.Fatalf("body = %q, want %q", got, "pong")
+	}
+}
+
+func TestMountNestedXXSMuxAlreadyBuilt(t *testing.T) {
+	sub := NewXXSMux()
+	sub.HandleFunc("GET /ping", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("pong"))
+	})
+	sub.Build()
+
+	mux := NewXXSMux()
+	mux.Mount("/api", sub)
+	mux.Build()
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/ping", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got := rec.Body.String(); got != "pong" {
+		t.Fatalf("body = %q, want %q", got, "pong")
+	}
+}
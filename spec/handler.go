@@ -0,0 +1,61 @@
+package spec
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/42LM/xxsmux"
+)
+
+// Option configures Handler.
+type Option func(*Info)
+
+// WithInfo sets the document's title and version. Defaults to "API"/"0.0.0".
+func WithInfo(info Info) Option {
+	return func(i *Info) { *i = info }
+}
+
+// Handler returns an http.Handler serving the OpenAPI 3.0 document
+// describing mux's routes at "/openapi.json", and a Swagger UI page
+// rendering it at "/docs".
+func Handler(mux *xxsmux.XXSMux, opts ...Option) http.Handler {
+	info := Info{Title: "API", Version: "0.0.0"}
+	for _, opt := range opts {
+		opt(&info)
+	}
+
+	h := http.NewServeMux()
+
+	h.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		doc, err := Generate(mux, info)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(doc)
+	})
+
+	h.HandleFunc("/docs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(swaggerUIPage))
+	})
+
+	return h
+}
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>API docs</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = () => SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"})
+  </script>
+</body>
+</html>
+`
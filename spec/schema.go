@@ -0,0 +1,91 @@
+package spec
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Schema is a (deliberately partial) OpenAPI 3 Schema Object: enough to
+// describe the Go structs this package reflects over.
+type Schema struct {
+	Ref        string            `json:"$ref,omitempty"`
+	Type       string            `json:"type,omitempty"`
+	Format     string            `json:"format,omitempty"`
+	Items      *Schema           `json:"items,omitempty"`
+	Properties map[string]Schema `json:"properties,omitempty"`
+}
+
+// schemaFor returns the Schema describing v's type, registering any named
+// struct types it encounters as reusable components (so repeated or nested
+// structs become $ref pointers instead of inlined duplicates).
+func schemaFor(v any, components map[string]Schema) Schema {
+	if v == nil {
+		return Schema{Type: "object"}
+	}
+	return schemaForType(reflect.TypeOf(v), components)
+}
+
+func schemaForType(t reflect.Type, components map[string]Schema) Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		name := t.Name()
+		if name == "" {
+			return structSchema(t, components)
+		}
+		if _, ok := components[name]; !ok {
+			// Reserve the name before recursing, so a struct that
+			// refers to itself doesn't loop forever.
+			components[name] = Schema{Type: "object"}
+			components[name] = structSchema(t, components)
+		}
+		return Schema{Ref: "#/components/schemas/" + name}
+
+	case reflect.Slice, reflect.Array:
+		elem := schemaForType(t.Elem(), components)
+		return Schema{Type: "array", Items: &elem}
+
+	case reflect.String:
+		return Schema{Type: "string"}
+
+	case reflect.Bool:
+		return Schema{Type: "boolean"}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{Type: "integer"}
+
+	case reflect.Float32, reflect.Float64:
+		return Schema{Type: "number"}
+
+	default:
+		return Schema{Type: "object"}
+	}
+}
+
+func structSchema(t reflect.Type, components map[string]Schema) Schema {
+	props := map[string]Schema{}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name := f.Name
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		if parts := strings.Split(tag, ","); parts[0] != "" {
+			name = parts[0]
+		}
+
+		props[name] = schemaForType(f.Type, components)
+	}
+
+	return Schema{Type: "object", Properties: props}
+}
@@ -0,0 +1,182 @@
+// Package spec generates an OpenAPI 3.0 document from the routes
+// registered on an xxsmux.XXSMux, using xxsmux.Walk to enumerate them and
+// the xxsmux.RouteMeta it passes for per-route documentation.
+package spec
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/42LM/xxsmux"
+)
+
+// Info is served as the document's top-level "info" object.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Document is a (partial) OpenAPI 3.0 document.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// PathItem maps an HTTP method (lower-case) to its Operation.
+type PathItem map[string]Operation
+
+// Operation describes a single method on a single path.
+type Operation struct {
+	Summary    string              `json:"summary,omitempty"`
+	Parameters []Parameter         `json:"parameters,omitempty"`
+	Responses  map[string]Response `json:"responses"`
+}
+
+// Parameter describes one request parameter.
+type Parameter struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+	Schema   Schema `json:"schema"`
+}
+
+// Response describes one possible response.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType is a content-type entry within a Response.
+type MediaType struct {
+	Schema Schema `json:"schema"`
+}
+
+// Components holds reusable schema definitions referenced by $ref.
+type Components struct {
+	Schemas map[string]Schema `json:"schemas,omitempty"`
+}
+
+var methods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+	http.MethodPatch, http.MethodDelete, http.MethodOptions, http.MethodTrace,
+}
+
+// Generate walks every route registered on mux and builds an OpenAPI 3.0
+// document describing it. Routes with a RouteMeta attached via Describe
+// get summaries, parameters and response schemas; undescribed routes
+// still show up, with a generic 200 response.
+func Generate(mux *xxsmux.XXSMux, info Info) (*Document, error) {
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Paths:   map[string]PathItem{},
+		Components: Components{
+			Schemas: map[string]Schema{},
+		},
+	}
+
+	err := mux.Walk(func(method, pattern string, handler http.Handler, _ []xxsmux.Middleware, meta xxsmux.RouteMeta) error {
+		// Mounted sub-handlers register a catch-all with no method;
+		// they have nothing meaningful to document at this level.
+		if method == "" && strings.Contains(pattern, "*") {
+			return nil
+		}
+
+		op := operationFor(pattern, meta, doc.Components.Schemas)
+
+		item, ok := doc.Paths[pattern]
+		if !ok {
+			item = PathItem{}
+		}
+
+		if method == "" {
+			for _, m := range methods {
+				item[strings.ToLower(m)] = op
+			}
+		} else {
+			item[strings.ToLower(method)] = op
+		}
+		doc.Paths[pattern] = item
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+func operationFor(pattern string, meta xxsmux.RouteMeta, components map[string]Schema) Operation {
+	op := Operation{
+		Responses: map[string]Response{
+			"200": {Description: "OK"},
+		},
+	}
+
+	for _, name := range pathParamNames(pattern) {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   Schema{Type: "string"},
+		})
+	}
+
+	if meta.Summary == "" && meta.Params == nil && meta.Responses == nil {
+		return op
+	}
+
+	op.Summary = meta.Summary
+
+	for _, p := range meta.Params {
+		if p.In == "path" {
+			// Override the inferred path parameter's schema type, if declared.
+			for i, existing := range op.Parameters {
+				if existing.Name == p.Name && existing.In == "path" {
+					op.Parameters[i].Schema = Schema{Type: p.Type}
+				}
+			}
+			continue
+		}
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:     p.Name,
+			In:       p.In,
+			Required: p.In == "path",
+			Schema:   Schema{Type: p.Type},
+		})
+	}
+
+	if len(meta.Responses) > 0 {
+		op.Responses = map[string]Response{}
+		statuses := make([]int, 0, len(meta.Responses))
+		for status := range meta.Responses {
+			statuses = append(statuses, status)
+		}
+		sort.Ints(statuses)
+		for _, status := range statuses {
+			schema := schemaFor(meta.Responses[status], components)
+			op.Responses[strconv.Itoa(status)] = Response{
+				Description: http.StatusText(status),
+				Content: map[string]MediaType{
+					"application/json": {Schema: schema},
+				},
+			}
+		}
+	}
+
+	return op
+}
+
+func pathParamNames(pattern string) []string {
+	var names []string
+	for _, seg := range strings.Split(pattern, "/") {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			names = append(names, seg[1:len(seg)-1])
+		}
+	}
+	return names
+}
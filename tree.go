@@ -0,0 +1,232 @@
+package xxsmux
+
+import (
+	"strings"
+)
+
+// nodeKind identifies what a route node matches against the path.
+type nodeKind uint8
+
+const (
+	staticKind nodeKind = iota
+	paramKind
+	catchAllKind
+)
+
+// route is a single node in the routing radix tree. Static nodes are keyed
+// by the first byte of their prefix so a lookup can pick the right child in
+// constant time before falling back to a full prefix comparison; param and
+// catchAll nodes match a whole path segment (or the remainder of the path)
+// regardless of its content.
+type route struct {
+	kind   nodeKind
+	prefix string // static: shared byte prefix. param/catchAll: segment name.
+
+	staticChildren map[byte]*route
+	paramChild     *route
+	catchAllChild  *route
+
+	// handlers holds the per-method endpoints registered at this exact
+	// node. A node only has handlers once a full pattern terminates here.
+	handlers map[string]*endpoint
+}
+
+func newRoute(kind nodeKind, prefix string) *route {
+	return &route{kind: kind, prefix: prefix}
+}
+
+// tree is a single method-agnostic radix tree holding every path registered on it.
+// Method dispatch happens at the leaf via route.handlers so that a path
+// match with no matching method can be reported as 405 instead of 404.
+type tree struct {
+	root *route
+}
+
+func newTree() *tree {
+	return &tree{root: newRoute(staticKind, "")}
+}
+
+// insert registers handler for method at pattern. pattern segments wrapped
+// in "{name}" become param children, a segment starting with "*" becomes a
+// catchAll child and must be the last segment of the pattern.
+func (t *tree) insert(pattern, method string, ep *endpoint) {
+	segments := splitSegments(pattern)
+	current := t.root
+
+	for i, seg := range segments {
+		switch {
+		case strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}"):
+			name := seg[1 : len(seg)-1]
+			if current.paramChild == nil {
+				current.paramChild = newRoute(paramKind, name)
+			}
+			current = current.paramChild
+
+		case strings.HasPrefix(seg, "*"):
+			name := strings.TrimPrefix(seg, "*")
+			if name == "" {
+				name = "*"
+			}
+			if current.catchAllChild == nil {
+				current.catchAllChild = newRoute(catchAllKind, name)
+			}
+			current = current.catchAllChild
+			// A catchAll consumes the remainder of the path, so it must
+			// be the last segment.
+			if i != len(segments)-1 {
+				panic("xxsmux: catch-all must be the last segment in pattern " + pattern)
+			}
+
+		default:
+			current = current.insertStatic(seg)
+		}
+	}
+
+	if current.handlers == nil {
+		current.handlers = map[string]*endpoint{}
+	}
+	current.handlers[method] = ep
+}
+
+// insertStatic walks (and splits, if needed) the static children of n to
+// make room for a node exactly matching segment, returning that node.
+func (n *route) insertStatic(segment string) *route {
+	if segment == "" {
+		return n
+	}
+
+	if n.staticChildren == nil {
+		n.staticChildren = map[byte]*route{}
+	}
+
+	child, ok := n.staticChildren[segment[0]]
+	if !ok {
+		newChild := newRoute(staticKind, segment)
+		n.staticChildren[segment[0]] = newChild
+		return newChild
+	}
+
+	common := longestCommonPrefix(child.prefix, segment)
+	switch {
+	case common == len(child.prefix) && common == len(segment):
+		// Exact match, reuse the existing node.
+		return child
+
+	case common == len(child.prefix):
+		// child.prefix is a full prefix of segment: recurse into child
+		// with the remaining suffix.
+		return child.insertStatic(segment[common:])
+
+	default:
+		// Split child at the common prefix so both the old suffix and
+		// the new suffix hang off a shared parent.
+		split := newRoute(staticKind, child.prefix[:common])
+		split.staticChildren = map[byte]*route{
+			child.prefix[common]: child,
+		}
+		child.prefix = child.prefix[common:]
+		n.staticChildren[segment[0]] = split
+
+		if common == len(segment) {
+			return split
+		}
+		return split.insertStatic(segment[common:])
+	}
+}
+
+// params accumulates path parameters captured while walking a lookup.
+type params map[string]string
+
+// lookup finds the node matching path, backtracking to param/catchAll
+// children whenever a static edge fails to match. It returns the matching
+// node (if any) and the params captured along the way. A non-nil node with
+// no handler for the requested method indicates a 405, not a 404.
+func (t *tree) lookup(path string) (*route, params) {
+	segments := splitSegments(path)
+	p := params{}
+	n := t.root.match(segments, p)
+	return n, p
+}
+
+func (n *route) match(segments []string, p params) *route {
+	if len(segments) == 0 {
+		if n.handlers != nil {
+			return n
+		}
+		// A catchAll may terminate here with an empty remainder.
+		if n.catchAllChild != nil {
+			p[n.catchAllChild.prefix] = ""
+			return n.catchAllChild
+		}
+		return nil
+	}
+
+	seg, rest := segments[0], segments[1:]
+
+	if child, ok := n.staticChildren[seg[0]]; ok && strings.HasPrefix(seg, child.prefix) {
+		trimmed := seg[len(child.prefix):]
+		var next []string
+		if trimmed == "" {
+			next = rest
+		} else {
+			next = append([]string{trimmed}, rest...)
+		}
+		if found := child.match(next, p); found != nil {
+			return found
+		}
+	}
+
+	if n.paramChild != nil {
+		if found := n.paramChild.match(rest, p); found != nil {
+			p[n.paramChild.prefix] = seg
+			return found
+		}
+	}
+
+	if n.catchAllChild != nil {
+		p[n.catchAllChild.prefix] = strings.Join(segments, "/")
+		return n.catchAllChild
+	}
+
+	return nil
+}
+
+// walk visits every endpoint registered anywhere in the tree.
+func (t *tree) walk(fn func(*endpoint)) {
+	t.root.walk(fn)
+}
+
+func (n *route) walk(fn func(*endpoint)) {
+	for _, ep := range n.handlers {
+		fn(ep)
+	}
+	for _, child := range n.staticChildren {
+		child.walk(fn)
+	}
+	if n.paramChild != nil {
+		n.paramChild.walk(fn)
+	}
+	if n.catchAllChild != nil {
+		n.catchAllChild.walk(fn)
+	}
+}
+
+func splitSegments(pattern string) []string {
+	pattern = strings.Trim(pattern, "/")
+	if pattern == "" {
+		return nil
+	}
+	return strings.Split(pattern, "/")
+}
+
+func longestCommonPrefix(a, b string) int {
+	max := len(a)
+	if len(b) < max {
+		max = len(b)
+	}
+	i := 0
+	for i < max && a[i] == b[i] {
+		i++
+	}
+	return i
+}
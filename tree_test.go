@@ -0,0 +1,82 @@
+package xxsmux
+
+import "testing"
+
+func TestTreeStaticAndParam(t *testing.T) {
+	tr := newTree()
+	tr.insert("/users/{id}", "GET", &endpoint{})
+	tr.insert("/users/me", "GET", &endpoint{})
+	tr.insert("/files/*filepath", "GET", &endpoint{})
+
+	tests := []struct {
+		path      string
+		wantFound bool
+		wantParam string
+		wantValue string
+	}{
+		{"/users/me", true, "", ""},
+		{"/users/42", true, "id", "42"},
+		{"/files/a/b/c.txt", true, "filepath", "a/b/c.txt"},
+		{"/nope", false, "", ""},
+	}
+
+	for _, tt := range tests {
+		node, p := tr.lookup(tt.path)
+		found := node != nil
+		if found != tt.wantFound {
+			t.Errorf("lookup(%q): found = %v, want %v", tt.path, found, tt.wantFound)
+			continue
+		}
+		if !found {
+			continue
+		}
+		if tt.wantParam != "" && p[tt.wantParam] != tt.wantValue {
+			t.Errorf("lookup(%q): param %q = %q, want %q", tt.path, tt.wantParam, p[tt.wantParam], tt.wantValue)
+		}
+	}
+}
+
+func TestTreeStaticTakesPrecedenceOverParam(t *testing.T) {
+	tr := newTree()
+	staticEp := &endpoint{}
+	paramEp := &endpoint{}
+	tr.insert("/users/me", "GET", staticEp)
+	tr.insert("/users/{id}", "GET", paramEp)
+
+	node, _ := tr.lookup("/users/me")
+	if node == nil || node.handlers["GET"] != staticEp {
+		t.Fatalf("lookup(/users/me) should prefer the static route over {id}")
+	}
+}
+
+func TestTreeLongestCommonPrefixSplit(t *testing.T) {
+	tr := newTree()
+	tr.insert("/team", "GET", &endpoint{})
+	tr.insert("/teapot", "GET", &endpoint{})
+
+	if node, _ := tr.lookup("/team"); node == nil {
+		t.Fatal("lookup(/team) = not found, want found")
+	}
+	if node, _ := tr.lookup("/teapot"); node == nil {
+		t.Fatal("lookup(/teapot) = not found, want found")
+	}
+	if node, _ := tr.lookup("/tea"); node != nil {
+		t.Fatal("lookup(/tea) = found, want not found")
+	}
+}
+
+func TestTreeMethodNotAllowed(t *testing.T) {
+	tr := newTree()
+	tr.insert("/widgets", "GET", &endpoint{})
+
+	node, _ := tr.lookup("/widgets")
+	if node == nil {
+		t.Fatal("lookup(/widgets) = not found, want found")
+	}
+	if _, ok := node.handlers["POST"]; ok {
+		t.Fatal("node.handlers[POST] should be absent so callers can report 405")
+	}
+	if _, ok := node.handlers["GET"]; !ok {
+		t.Fatal("node.handlers[GET] should be present")
+	}
+}
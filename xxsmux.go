@@ -0,0 +1,427 @@
+// Package xxsmux implements a small HTTP router on top of net/http.
+//
+// Routes are matched with a radix tree (as opposed to Go 1.22's
+// http.ServeMux, which xxsmux does not depend on) so that dynamic segments
+// such as "/users/{id}" and catch-all segments such as "/files/*filepath"
+// are supported directly. Middlewares are composed per route via Use, and
+// Build wires every registered route and its middleware stack into a
+// ServeHTTP-able XXSMux.
+package xxsmux
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Middleware represents an http.Handler wrapper to inject additional functionality.
+type Middleware func(http.Handler) http.Handler
+
+// XXSMux is an HTTP router. The zero value is not usable; construct one
+// with NewXXSMux.
+type XXSMux struct {
+	tree          *tree
+	patternPrefix string
+	hostPattern   string
+	middlewares   []Middleware
+
+	root   *XXSMux
+	parent *XXSMux
+
+	subXXSMux []*XXSMux
+
+	notFoundHandler         http.Handler
+	methodNotAllowedHandler http.Handler
+
+	// built is only ever set on the root. Once set, Handle/HandleFunc
+	// panic: the effective middleware stacks have already been resolved
+	// and baked into the tree by Build.
+	built bool
+
+	// registeredPatterns is only ever appended to on the root; it backs
+	// PrintRegisteredPatterns.
+	registeredPatterns []string
+
+	// hostTrees is only ever populated on the root: one route tree per
+	// distinct Host() pattern, looked up before falling back to tree.
+	hostTrees map[string]*tree
+
+	// mountedSubMux is only ever appended to on the root: every *XXSMux
+	// passed to Mount, so Build can cascade into them without requiring
+	// callers to Build each one themselves before mounting it.
+	mountedSubMux []*XXSMux
+}
+
+// endpoint is what the route tree actually stores at a leaf: the raw,
+// unwrapped handler plus the XXSMux node it was registered on, so Build
+// can resolve its effective middleware stack from the mux hierarchy.
+type endpoint struct {
+	method  string
+	pattern string
+	handler http.Handler
+	mux     *XXSMux
+	built   http.Handler
+	meta    RouteMeta
+}
+
+// NewXXSMux returns a new, empty XXSMux ready to have routes registered on it.
+func NewXXSMux() *XXSMux {
+	mux := &XXSMux{tree: newTree()}
+	mux.root = mux
+	mux.parent = mux
+	return mux
+}
+
+// Handle registers handler for pattern. pattern is of the form
+// "METHOD /path", mirroring net/http's own convention; the method may be
+// omitted ("/path") to match any method. Path segments wrapped in "{name}"
+// capture a single path parameter, and a segment of the form "*name"
+// captures the remainder of the path.
+//
+// Handle panics if called after Build. It returns a *Route so callers can
+// attach documentation with Describe.
+func (mux *XXSMux) Handle(pattern string, handler http.Handler) *Route {
+	if mux.root.built {
+		panic("xxsmux: Handle called after Build")
+	}
+	method, patternPath := splitPattern(pattern)
+	fullPattern := removeDoubleSlash(mux.patternPrefix + "/" + patternPath)
+	ep := &endpoint{
+		method:  method,
+		pattern: fullPattern,
+		handler: handler,
+		mux:     mux,
+	}
+	mux.targetTree().insert(fullPattern, method, ep)
+
+	registered := fullPattern
+	if method != "" {
+		registered = method + " " + fullPattern
+	}
+	if mux.hostPattern != "" {
+		registered = mux.hostPattern + registered
+	}
+	mux.root.registeredPatterns = append(mux.root.registeredPatterns, registered)
+
+	return &Route{ep: ep}
+}
+
+// targetTree returns the route tree mux's routes are inserted into: its
+// host's tree if mux descends from a Host() call, or the default
+// host-agnostic tree otherwise.
+func (mux *XXSMux) targetTree() *tree {
+	if mux.hostPattern == "" {
+		return mux.root.tree
+	}
+	root := mux.root
+	if root.hostTrees == nil {
+		root.hostTrees = map[string]*tree{}
+	}
+	t, ok := root.hostTrees[mux.hostPattern]
+	if !ok {
+		t = newTree()
+		root.hostTrees[mux.hostPattern] = t
+	}
+	return t
+}
+
+// HandleFunc registers handlerFunc for pattern. See Handle for the pattern syntax.
+func (mux *XXSMux) HandleFunc(pattern string, handlerFunc http.HandlerFunc) *Route {
+	return mux.Handle(pattern, handlerFunc)
+}
+
+// With returns an inline XXSMux that applies the given middlewares in
+// addition to mux's own, without mutating mux. It's meant for one-off
+// chaining, e.g. mux.With(RequireAuth).Handle(...).
+func (mux *XXSMux) With(mw ...Middleware) *XXSMux {
+	sub := mux.Subrouter()
+	sub.Use(mw...)
+	return sub
+}
+
+// Group scopes fn to an inline XXSMux that shares mux's prefix, letting fn
+// add middlewares or routes without affecting mux or routes registered on
+// mux after Group returns.
+func (mux *XXSMux) Group(fn func(r *XXSMux)) *XXSMux {
+	sub := mux.Subrouter()
+	if fn != nil {
+		fn(sub)
+	}
+	return sub
+}
+
+// Route mounts a new XXSMux under prefix and scopes fn to it.
+func (mux *XXSMux) Route(prefix string, fn func(r *XXSMux)) *XXSMux {
+	sub := mux.Subrouter()
+	sub.Prefix(prefix)
+	if fn != nil {
+		fn(sub)
+	}
+	return sub
+}
+
+// Host returns a subrouter that only matches requests whose Host header
+// (port ignored) matches pattern. pattern is either a literal host
+// ("api.example.com") or a single wildcard subdomain label followed by a
+// literal suffix ("{tenant}.example.com"); a captured subdomain is
+// retrieved the same way a path parameter is, via URLParam.
+func (mux *XXSMux) Host(pattern string) *XXSMux {
+	sub := mux.Subrouter()
+	sub.hostPattern = pattern
+	return sub
+}
+
+// Mount attaches h under prefix, stripping prefix from the request path
+// before delegating to it. h can be anything implementing http.Handler —
+// another *XXSMux, an http.FileServer, a third-party handler. The mounted
+// handler is wrapped by mux's middleware stack like any other route once
+// Build runs, and if h is itself an *XXSMux, its own registered patterns
+// are folded into PrintRegisteredPatterns under prefix.
+//
+// If h is an *XXSMux, it does not need to have had Build called on it
+// already: mux's own Build cascades into every mounted *XXSMux for you.
+func (mux *XXSMux) Mount(prefix string, h http.Handler) {
+	if len(prefix) > 0 && prefix[0] != '/' {
+		prefix = "/" + prefix
+	}
+	prefix = strings.TrimSuffix(prefix, "/")
+	fullPrefix := removeDoubleSlash(mux.patternPrefix + "/" + prefix)
+
+	mux.Handle(prefix+"/*xxsmuxMount", http.StripPrefix(fullPrefix, h))
+
+	if sub, ok := h.(*XXSMux); ok {
+		for _, p := range sub.root.registeredPatterns {
+			method, patternPath := splitPattern(p)
+			folded := fullPrefix + patternPath
+			if method != "" {
+				folded = method + " " + folded
+			}
+			mux.root.registeredPatterns = append(mux.root.registeredPatterns, folded)
+		}
+		mux.root.mountedSubMux = append(mux.root.mountedSubMux, sub)
+	}
+}
+
+// PrintRegisteredPatterns prints every pattern registered on mux, including
+// those folded in from mounted sub-handlers.
+func (mux *XXSMux) PrintRegisteredPatterns() {
+	fmt.Println("* Registered patterns:", strings.Repeat("*", 47))
+	fmt.Println(strings.Join(mux.root.registeredPatterns, "\n"))
+	fmt.Printf("%s\n\n", strings.Repeat("*", 70))
+}
+
+// NotFound sets the handler invoked when no route matches the request path.
+func (mux *XXSMux) NotFound(handler http.Handler) {
+	mux.root.notFoundHandler = handler
+}
+
+// MethodNotAllowed sets the handler invoked when a route matches the
+// request path but not its method.
+func (mux *XXSMux) MethodNotAllowed(handler http.Handler) {
+	mux.root.methodNotAllowedHandler = handler
+}
+
+// Use appends middlewares to the mux. Build resolves every route's stack
+// from the middlewares present at Build time, so they apply to all routes
+// registered on mux and its subrouters, whether registered before or after
+// the call to Use.
+func (mux *XXSMux) Use(middleware ...Middleware) {
+	mux.middlewares = append(mux.middlewares, middleware...)
+}
+
+// Prefix sets the path prefix routes registered on mux are mounted under.
+func (mux *XXSMux) Prefix(prefix string) {
+	if len(prefix) > 0 && prefix[0] != '/' {
+		prefix = "/" + prefix
+	}
+	mux.patternPrefix += prefix
+}
+
+// Subrouter returns a new XXSMux sharing the same underlying route tree and
+// inheriting the current prefix. Its own middleware stack starts empty;
+// Build resolves the effective stack of every route by walking up to the
+// root, so a subrouter's ancestors' middlewares still apply without being
+// copied in here.
+func (mux *XXSMux) Subrouter() *XXSMux {
+	subMux := &XXSMux{
+		tree:          mux.root.tree,
+		patternPrefix: mux.patternPrefix,
+		hostPattern:   mux.hostPattern,
+		root:          mux.root,
+		parent:        mux,
+	}
+	mux.subXXSMux = append(mux.subXXSMux, subMux)
+	return subMux
+}
+
+// Build resolves the effective middleware stack of every route registered
+// anywhere in mux's tree exactly once — the chain of each route's
+// ancestors' middlewares, in registration order, followed by its own — and
+// freezes the mux so further calls to Handle/HandleFunc panic. It also
+// cascades into every *XXSMux passed to Mount, so a tree of mounted
+// routers only ever needs Build called on its outermost mux.
+func (mux *XXSMux) Build() {
+	root := mux.root
+	build := func(ep *endpoint) {
+		ep.built = NewHandler(effectiveMiddlewares(ep.mux)...)(ep.handler)
+	}
+	root.tree.walk(build)
+	for _, t := range root.hostTrees {
+		t.walk(build)
+	}
+	for _, sub := range root.mountedSubMux {
+		if !sub.root.built {
+			sub.Build()
+		}
+	}
+	root.built = true
+}
+
+// Walk calls fn once for every route registered anywhere in mux's tree,
+// passing the route's method (empty if it matches any method), full
+// pattern, raw unwrapped handler, its effective middleware stack, and the
+// RouteMeta attached to it via Route.Describe (the zero value if Describe
+// was never called). Walk stops and returns the first error fn returns.
+// Host-scoped routes are reported with their pattern prefixed by the host
+// pattern, e.g. "{tenant}.example.com/users/{id}", mirroring how Go 1.22's
+// http.ServeMux itself spells host-scoped patterns.
+func (mux *XXSMux) Walk(fn func(method, pattern string, handler http.Handler, mws []Middleware, meta RouteMeta) error) error {
+	var walkErr error
+	visit := func(hostPattern string) func(*endpoint) {
+		return func(ep *endpoint) {
+			if walkErr != nil {
+				return
+			}
+			walkErr = fn(ep.method, hostPattern+ep.pattern, ep.handler, effectiveMiddlewares(ep.mux), ep.meta)
+		}
+	}
+
+	mux.root.tree.walk(visit(""))
+	for hostPattern, t := range mux.root.hostTrees {
+		t.walk(visit(hostPattern))
+	}
+	return walkErr
+}
+
+// effectiveMiddlewares returns mux's middleware stack prefixed by every
+// ancestor's, root first.
+func effectiveMiddlewares(mux *XXSMux) []Middleware {
+	if mux.parent == mux {
+		return append([]Middleware{}, mux.middlewares...)
+	}
+	return append(effectiveMiddlewares(mux.parent), mux.middlewares...)
+}
+
+// ServeHTTP implements http.Handler, dispatching to the registered route
+// tree. A path match with no handler for the request method results in a
+// 405; no path match at all results in a 404.
+//
+// Build must be called before ServeHTTP is used to serve requests.
+func (mux *XXSMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	node, p := mux.root.lookup(r)
+	if node == nil {
+		mux.notFound(w, r)
+		return
+	}
+
+	ep, ok := node.handlers[r.Method]
+	if !ok {
+		ep, ok = node.handlers[""]
+	}
+	if !ok {
+		mux.methodNotAllowed(w, r)
+		return
+	}
+
+	if len(p) > 0 {
+		r = withParams(r, p)
+	}
+	ep.built.ServeHTTP(w, r)
+}
+
+// lookup resolves r against a host-scoped tree first (if any Host pattern
+// matches r.Host), falling back to the default, host-agnostic tree.
+//
+// More than one registered Host pattern can match the same r.Host — e.g. a
+// literal "api.example.com" alongside a wildcard "{tenant}.example.com"
+// also matches "api.example.com". mux.hostTrees is a plain map, so iterating
+// it directly would pick whichever happens to come up first under Go's
+// randomized map iteration. To keep this deterministic, literal patterns
+// are tried before wildcard ones, mirroring how the path tree itself
+// already prefers staticChildren over paramChild.
+func (mux *XXSMux) lookup(r *http.Request) (*route, params) {
+	for _, hostPattern := range mux.orderedHostPatterns() {
+		hostParams, ok := matchHost(hostPattern, r.Host)
+		if !ok {
+			continue
+		}
+		if node, p := mux.hostTrees[hostPattern].lookup(r.URL.Path); node != nil {
+			for name, value := range hostParams {
+				p[name] = value
+			}
+			return node, p
+		}
+	}
+	return mux.tree.lookup(r.URL.Path)
+}
+
+// orderedHostPatterns returns mux.hostTrees' keys with literal (non-wildcard)
+// patterns first, each group sorted for determinism across runs.
+func (mux *XXSMux) orderedHostPatterns() []string {
+	var literal, wildcard []string
+	for hostPattern := range mux.hostTrees {
+		if strings.HasPrefix(hostPattern, "{") {
+			wildcard = append(wildcard, hostPattern)
+		} else {
+			literal = append(literal, hostPattern)
+		}
+	}
+	sort.Strings(literal)
+	sort.Strings(wildcard)
+	return append(literal, wildcard...)
+}
+
+func (mux *XXSMux) notFound(w http.ResponseWriter, r *http.Request) {
+	if h := mux.root.notFoundHandler; h != nil {
+		h.ServeHTTP(w, r)
+		return
+	}
+	http.NotFound(w, r)
+}
+
+func (mux *XXSMux) methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	if h := mux.root.methodNotAllowedHandler; h != nil {
+		h.ServeHTTP(w, r)
+		return
+	}
+	http.Error(w, "405 method not allowed", http.StatusMethodNotAllowed)
+}
+
+// NewHandler returns an http.Handler wrapped with the given middlewares,
+// applied in reverse so that the first middleware runs outermost — the
+// same ordering every other net/http-based router in the ecosystem uses.
+func NewHandler(mw ...Middleware) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		for i := len(mw) - 1; i >= 0; i-- {
+			h = mw[i](h)
+		}
+		return h
+	}
+}
+
+// splitPattern splits a "METHOD /path" pattern into its method and path.
+// The method is empty when pattern has no method prefix.
+func splitPattern(pattern string) (method, patternPath string) {
+	if i := strings.IndexByte(pattern, ' '); i != -1 {
+		return pattern[:i], pattern[i+1:]
+	}
+	return "", pattern
+}
+
+func removeDoubleSlash(path string) string {
+	for strings.Contains(path, "//") {
+		path = strings.ReplaceAll(path, "//", "/")
+	}
+	return path
+}